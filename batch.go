@@ -0,0 +1,230 @@
+package smsmts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchSender splits an arbitrarily large slice of SubmitMsg into
+// API-sized sub-batches, sends them concurrently through a bounded worker
+// pool, and enforces a token-bucket rate limit, so bulk campaigns don't
+// hammer the endpoint or trip HTTP 429s.
+type BatchSender struct {
+	Sender Sender
+
+	// MaxBatchSize is how many messages go into one SubmitBatch.
+	MaxBatchSize int
+	// MaxConcurrency bounds how many sub-batches are in flight at once.
+	MaxConcurrency int
+	// RequestsPerSecond and Burst configure the token-bucket rate limit
+	// shared across all sub-batches. RequestsPerSecond <= 0 disables
+	// rate limiting.
+	RequestsPerSecond float64
+	Burst             int
+
+	limiterOnce sync.Once
+	limiter     *tokenBucket
+}
+
+// BatchSenderOption configures a BatchSender constructed via NewBatchSender.
+type BatchSenderOption func(*BatchSender)
+
+// WithMaxBatchSize sets how many messages go into one SubmitBatch.
+func WithMaxBatchSize(n int) BatchSenderOption {
+	return func(b *BatchSender) { b.MaxBatchSize = n }
+}
+
+// WithMaxConcurrency bounds how many sub-batches are sent at once.
+func WithMaxConcurrency(n int) BatchSenderOption {
+	return func(b *BatchSender) { b.MaxConcurrency = n }
+}
+
+// WithRateLimit enforces a token-bucket rate limit of requestsPerSecond
+// sub-batches per second, allowing bursts of up to burst.
+func WithRateLimit(requestsPerSecond float64, burst int) BatchSenderOption {
+	return func(b *BatchSender) {
+		b.RequestsPerSecond = requestsPerSecond
+		b.Burst = burst
+	}
+}
+
+// NewBatchSender creates a BatchSender that submits through sender.
+func NewBatchSender(sender Sender, opts ...BatchSenderOption) *BatchSender {
+	b := &BatchSender{
+		Sender:         sender,
+		MaxBatchSize:   100,
+		MaxConcurrency: 4,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *BatchSender) rateLimiter() *tokenBucket {
+	b.limiterOnce.Do(func() {
+		if b.RequestsPerSecond > 0 {
+			b.limiter = newTokenBucket(b.RequestsPerSecond, b.Burst)
+		}
+	})
+	return b.limiter
+}
+
+// BatchSendResult summarizes a BatchSender.Send call.
+type BatchSendResult struct {
+	Sent           int
+	Failed         int
+	PerBatchErrors []error
+}
+
+// Send splits msgs into MaxBatchSize-sized sub-batches and submits them
+// concurrently (bounded by MaxConcurrency and the configured rate limit).
+// Results are written back into msgs in place (MessageID/SendError per
+// element), same as SendSMS does for a single batch, and a BatchSendResult
+// summarizes the outcome.
+func (b *BatchSender) Send(ctx context.Context, msgs []SubmitMsg) (*BatchSendResult, error) {
+	maxBatchSize := b.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = 100
+	}
+	maxConcurrency := b.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	type chunk struct {
+		start int
+		batch *SubmitBatch
+	}
+
+	var chunks []chunk
+	for start := 0; start < len(msgs); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+		submits := make([]SubmitMsg, end-start)
+		copy(submits, msgs[start:end])
+		chunks = append(chunks, chunk{start: start, batch: &SubmitBatch{Submits: submits}})
+	}
+
+	result := &BatchSendResult{PerBatchErrors: make([]error, len(chunks))}
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, ch := range chunks {
+		i, ch := i, ch
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			result.PerBatchErrors[i] = ctx.Err()
+			result.Failed += len(ch.batch.Submits)
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if limiter := b.rateLimiter(); limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					mu.Lock()
+					result.PerBatchErrors[i] = err
+					result.Failed += len(ch.batch.Submits)
+					mu.Unlock()
+					return
+				}
+			}
+
+			err := b.Sender.Send(ctx, ch.batch)
+
+			mu.Lock()
+			defer mu.Unlock()
+			copy(msgs[ch.start:ch.start+len(ch.batch.Submits)], ch.batch.Submits)
+			if err != nil {
+				result.PerBatchErrors[i] = fmt.Errorf("batch %d: %v", i, err)
+				result.Failed += len(ch.batch.Submits)
+				return
+			}
+			for _, m := range ch.batch.Submits {
+				if m.SendError {
+					result.Failed++
+				} else {
+					result.Sent++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// tokenBucket is a minimal token-bucket rate limiter: refillPerSec tokens
+// accrue per second, up to a cap of burst.
+type tokenBucket struct {
+	mu           sync.Mutex
+	tokens       float64
+	burst        float64
+	refillPerSec float64
+	last         time.Time
+}
+
+func newTokenBucket(refillPerSec float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		tokens:       float64(burst),
+		burst:        float64(burst),
+		refillPerSec: refillPerSec,
+		last:         time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is cancelled.
+func (t *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := t.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, or returns how
+// long the caller should wait before trying again.
+func (t *tokenBucket) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(t.last)
+	t.last = now
+	t.tokens += elapsed.Seconds() * t.refillPerSec
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+
+	if t.tokens >= 1 {
+		t.tokens--
+		return 0
+	}
+
+	missing := 1 - t.tokens
+	return time.Duration(missing / t.refillPerSec * float64(time.Second))
+}
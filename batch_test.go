@@ -0,0 +1,193 @@
+package smsmts
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyTrackingSender assigns each submit a MessageID derived from its
+// MsID and records the maximum number of Send calls that were in flight at
+// once, so tests can assert MaxConcurrency is actually honored.
+type concurrencyTrackingSender struct {
+	inFlight    int32
+	maxInFlight int32
+	failEvery   int // if > 0, every Nth submit (by MsID suffix) gets SendError
+	delay       time.Duration
+}
+
+func (s *concurrencyTrackingSender) Send(ctx context.Context, batch *SubmitBatch) error {
+	cur := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&s.maxInFlight)
+		if cur <= max || atomic.CompareAndSwapInt32(&s.maxInFlight, max, cur) {
+			break
+		}
+	}
+
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for i := range batch.Submits {
+		var n int
+		fmt.Sscanf(batch.Submits[i].MsID, "7900%d", &n)
+		batch.Submits[i].MessageID = n + 1
+		if s.failEvery > 0 && n%s.failEvery == 0 {
+			batch.Submits[i].SendError = true
+		}
+	}
+	return nil
+}
+
+func (s *concurrencyTrackingSender) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	return nil, nil
+}
+
+func makeSubmitMsgs(n int) []SubmitMsg {
+	msgs := make([]SubmitMsg, n)
+	for i := range msgs {
+		msgs[i] = SubmitMsg{MsID: fmt.Sprintf("7900%07d", i), Message: "hi"}
+	}
+	return msgs
+}
+
+func TestBatchSender_ChunksAndRespectsMaxConcurrency(t *testing.T) {
+	sender := &concurrencyTrackingSender{delay: 10 * time.Millisecond}
+	b := NewBatchSender(sender, WithMaxBatchSize(10), WithMaxConcurrency(3))
+
+	msgs := makeSubmitMsgs(47)
+	result, err := b.Send(context.Background(), msgs)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if result.Sent != 47 || result.Failed != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if max := atomic.LoadInt32(&sender.maxInFlight); max > 3 {
+		t.Errorf("maxInFlight = %d, want <= 3", max)
+	}
+
+	for i, m := range msgs {
+		if m.MessageID != i+1 {
+			t.Fatalf("msgs[%d].MessageID = %d, want %d (write-back must preserve order)", i, m.MessageID, i+1)
+		}
+	}
+}
+
+func TestBatchSender_AggregatesSentAndFailed(t *testing.T) {
+	sender := &concurrencyTrackingSender{failEvery: 3}
+	b := NewBatchSender(sender, WithMaxBatchSize(5), WithMaxConcurrency(2))
+
+	msgs := makeSubmitMsgs(12)
+	result, err := b.Send(context.Background(), msgs)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	wantFailed := 0
+	for i := 0; i < 12; i++ {
+		if i%3 == 0 {
+			wantFailed++
+		}
+	}
+	if result.Failed != wantFailed || result.Sent != 12-wantFailed {
+		t.Errorf("result = %+v, want Sent=%d Failed=%d", result, 12-wantFailed, wantFailed)
+	}
+}
+
+func TestBatchSender_DefaultsApplyWhenUnset(t *testing.T) {
+	sender := &concurrencyTrackingSender{}
+	b := &BatchSender{Sender: sender}
+
+	msgs := makeSubmitMsgs(3)
+	result, err := b.Send(context.Background(), msgs)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if result.Sent != 3 {
+		t.Errorf("result = %+v, want Sent=3", result)
+	}
+}
+
+func TestBatchSender_ContextCancelledMarksRemainingFailed(t *testing.T) {
+	sender := &concurrencyTrackingSender{delay: 50 * time.Millisecond}
+	b := NewBatchSender(sender, WithMaxBatchSize(1), WithMaxConcurrency(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	msgs := makeSubmitMsgs(5)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	result, err := b.Send(ctx, msgs)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if result.Failed == 0 {
+		t.Error("expected some sub-batches to fail after context cancellation")
+	}
+	foundCancelErr := false
+	for _, pbErr := range result.PerBatchErrors {
+		if pbErr == context.Canceled {
+			foundCancelErr = true
+		}
+	}
+	if !foundCancelErr {
+		t.Errorf("expected at least one PerBatchErrors entry to be context.Canceled, got %v", result.PerBatchErrors)
+	}
+}
+
+func TestBatchSender_RateLimitSpacesOutRequests(t *testing.T) {
+	sender := &concurrencyTrackingSender{}
+	b := NewBatchSender(sender,
+		WithMaxBatchSize(1),
+		WithMaxConcurrency(1),
+		WithRateLimit(20, 1), // 1 burst, then 1 every 50ms
+	)
+
+	msgs := makeSubmitMsgs(3)
+	start := time.Now()
+	if _, err := b.Send(context.Background(), msgs); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 80*time.Millisecond {
+		t.Errorf("expected rate limiting to space out 3 requests over >= ~100ms, took %s", elapsed)
+	}
+}
+
+func TestTokenBucket_AllowsBurstThenThrottles(t *testing.T) {
+	tb := newTokenBucket(10, 2) // 2 burst, refill 10/sec (~100ms per token)
+
+	if d := tb.reserve(); d != 0 {
+		t.Errorf("first reserve() should be free, got delay %s", d)
+	}
+	if d := tb.reserve(); d != 0 {
+		t.Errorf("second reserve() (within burst) should be free, got delay %s", d)
+	}
+	if d := tb.reserve(); d <= 0 {
+		t.Error("third reserve() should require waiting once the burst is exhausted")
+	}
+}
+
+func TestTokenBucket_WaitRespectsContextCancellation(t *testing.T) {
+	tb := newTokenBucket(1, 1)
+	tb.reserve() // exhaust the single token
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := tb.Wait(ctx); err == nil {
+		t.Error("expected Wait to return an error for an already-cancelled context")
+	}
+}
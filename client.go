@@ -0,0 +1,266 @@
+package smsmts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is a reusable MTS omni-adapter client. Unlike the package-level
+// functions it does not rely on mutable package state, so a program can
+// safely hold several Clients with different tokens/endpoints and use them
+// concurrently (e.g. one per tenant).
+type Client struct {
+	Token      string
+	BaseURL    string
+	HTTPClient *http.Client
+	Logger     *log.Logger
+	Timeout    time.Duration
+	UserAgent  string
+
+	// statusURLTempl defaults to MessageStatusEndpointTempl, is derived from
+	// the new BaseURL by WithBaseURL, and can be overridden independently
+	// after that; this is what lets the legacy package-level
+	// functions keep honoring SendMessageEndpoint/MessageStatusEndpointTempl.
+	statusURLTempl string
+}
+
+// Option configures a Client constructed via NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the *http.Client used for requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.HTTPClient = hc }
+}
+
+// WithBaseURL overrides the default send-messages endpoint.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.BaseURL = url
+		c.statusURLTempl = url + "/status?messageIDs=%s"
+	}
+}
+
+// WithTimeout sets the request timeout used when HTTPClient is not provided.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.Timeout = d }
+}
+
+// WithLogger attaches a logger used to report per-request diagnostics.
+func WithLogger(l *log.Logger) Option {
+	return func(c *Client) { c.Logger = l }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.UserAgent = ua }
+}
+
+// withStatusURLTempl overrides the status endpoint independently of
+// BaseURL. It is unexported: the only caller is the backward-compatible
+// package-level GetSMSStatuses/GetSMSStatus, which must keep honoring
+// MessageStatusEndpointTempl even when it is unrelated to SendMessageEndpoint.
+func withStatusURLTempl(tmpl string) Option {
+	return func(c *Client) { c.statusURLTempl = tmpl }
+}
+
+// NewClient creates a Client for the given API token. Without options it
+// targets the same production endpoints as the package-level functions.
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
+		Token:          token,
+		BaseURL:        SendMessageEndpoint,
+		Timeout:        time.Duration(QueryTimeoutSec) * time.Second,
+		statusURLTempl: MessageStatusEndpointTempl,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: c.Timeout}
+}
+
+func (c *Client) setCommonHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+}
+
+func (c *Client) logf(format string, args ...any) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, args...)
+	}
+}
+
+// StatusError is returned by Client methods when the server responds with a
+// non-2xx HTTP status. It carries the status code so callers building retry
+// or failover logic (see RetryPolicy, FailoverSender) can decide whether
+// the failure is worth retrying without re-parsing the error string.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *StatusError) Error() string { return e.Err.Error() }
+
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// SendSMSContext sends a batch of SMS messages, aborting early if ctx is
+// cancelled.
+func (c *Client) SendSMSContext(ctx context.Context, batch *SubmitBatch) error {
+	_, _, err := c.sendSMSOnce(ctx, batch, "")
+	return err
+}
+
+// sendSMSOnce performs a single send attempt. When idempotencyKey is
+// non-empty it is sent as the Idempotency-Key header, letting retrying
+// callers (see SendSMSWithRetry) resubmit without risking duplicate
+// delivery. It returns the HTTP status code (0 if the request never
+// reached the server) and, if the server asked to slow down, the
+// Retry-After delay, so a retry loop can make a retryable/non-retryable
+// decision without re-parsing the response itself.
+func (c *Client) sendSMSOnce(ctx context.Context, batch *SubmitBatch, idempotencyKey string) (statusCode int, retryAfter time.Duration, err error) {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return 0, 0, fmt.Errorf("NewRequestWithContext(): %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.setCommonHeaders(req)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		c.logf("smsmts: SendSMSContext: client.Do(): %v", err)
+		return 0, 0, fmt.Errorf("client.Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("http status: %d with token: %s", resp.StatusCode, c.Token)
+		return resp.StatusCode, retryAfter, &StatusError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, retryAfter, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var respStruct SendResponse
+	if err := json.Unmarshal(body, &respStruct); err != nil {
+		return resp.StatusCode, retryAfter, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	for _, sbRes := range respStruct.Data.SubmitResults {
+		for i := range batch.Submits {
+			if batch.Submits[i].MsID == sbRes.MsID {
+				batch.Submits[i].MessageID = sbRes.MessageID
+				if sbRes.Code != "OK" {
+					batch.Submits[i].SendError = true
+				}
+				break
+			}
+		}
+	}
+
+	if respStruct.Status != 0 {
+		return resp.StatusCode, retryAfter, fmt.Errorf("error: %s", respStruct.Description)
+	}
+
+	return resp.StatusCode, retryAfter, nil
+}
+
+// GetSMSStatusesContext fetches statuses for the given message IDs, aborting
+// early if ctx is cancelled.
+func (c *Client) GetSMSStatusesContext(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	if len(messageIDs) == 0 {
+		return []MessageStatus{}, nil
+	}
+
+	idStrs := make([]string, len(messageIDs))
+	for i, id := range messageIDs {
+		idStrs[i] = strconv.Itoa(id)
+	}
+	idsParam := strings.Join(idStrs, ",")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(c.statusURLTempl, idsParam), nil)
+	if err != nil {
+		return nil, fmt.Errorf("NewRequestWithContext(): %v", err)
+	}
+	c.setCommonHeaders(req)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		c.logf("smsmts: GetSMSStatusesContext: client.Do(): %v", err)
+		return nil, fmt.Errorf("client.Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("http status: %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var respStruct StatResponse
+	if err := json.Unmarshal(body, &respStruct); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal(): %v, body: %s", err, string(body))
+	}
+
+	if respStruct.Code != 0 {
+		return nil, fmt.Errorf("API error: %s", respStruct.Description)
+	}
+
+	var allStatuses []MessageStatus
+	for _, dataItem := range respStruct.Data {
+		for _, stRes := range dataItem.Statuses {
+			allStatuses = append(allStatuses, MessageStatus{
+				MessageID: strconv.Itoa(dataItem.MessageID),
+				MsID:      stRes.MsID,
+				Status:    stRes.Status,
+				Cost:      stRes.Cost,
+			})
+		}
+	}
+
+	return allStatuses, nil
+}
+
+// GetSMSStatusContext returns the status for a single message ID, aborting
+// early if ctx is cancelled.
+func (c *Client) GetSMSStatusContext(ctx context.Context, messageID int) (*MessageStatus, error) {
+	statuses, err := c.GetSMSStatusesContext(ctx, []int{messageID})
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("no status found for message ID %d", messageID)
+	}
+	return &statuses[0], nil
+}
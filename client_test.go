@@ -0,0 +1,243 @@
+package smsmts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewClient_Defaults(t *testing.T) {
+	origEndpoint := SendMessageEndpoint
+	origStatusTempl := MessageStatusEndpointTempl
+	defer func() {
+		SendMessageEndpoint = origEndpoint
+		MessageStatusEndpointTempl = origStatusTempl
+	}()
+
+	c := NewClient("tok")
+	if c.Token != "tok" {
+		t.Errorf("Token = %q, want %q", c.Token, "tok")
+	}
+	if c.BaseURL != SendMessageEndpoint {
+		t.Errorf("BaseURL = %q, want %q", c.BaseURL, SendMessageEndpoint)
+	}
+	if c.statusURLTempl != MessageStatusEndpointTempl {
+		t.Errorf("statusURLTempl = %q, want %q", c.statusURLTempl, MessageStatusEndpointTempl)
+	}
+}
+
+func TestWithBaseURL_AlsoDerivesStatusURL(t *testing.T) {
+	c := NewClient("tok", WithBaseURL("https://example.test/messages"))
+	if c.BaseURL != "https://example.test/messages" {
+		t.Errorf("BaseURL = %q", c.BaseURL)
+	}
+	want := "https://example.test/messages/status?messageIDs=%s"
+	if c.statusURLTempl != want {
+		t.Errorf("statusURLTempl = %q, want %q", c.statusURLTempl, want)
+	}
+}
+
+func TestWithUserAgent_SetsHeader(t *testing.T) {
+	var gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", WithBaseURL(server.URL), WithUserAgent("smsmts-test/1.0"))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+	if err := c.SendSMSContext(context.Background(), batch); err != nil {
+		t.Fatalf("SendSMSContext failed: %v", err)
+	}
+	if gotUA != "smsmts-test/1.0" {
+		t.Errorf("User-Agent = %q, want %q", gotUA, "smsmts-test/1.0")
+	}
+}
+
+func TestWithLogger_ReceivesDiagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var logBuf strings.Builder
+	logger := log.New(&logBuf, "", 0)
+
+	c := NewClient("tok", WithBaseURL(server.URL), WithLogger(logger))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+	if err := c.SendSMSContext(context.Background(), batch); err == nil {
+		t.Fatal("expected error")
+	}
+	// StatusError is returned directly without going through logf, but a
+	// transport-level failure does; exercise that path instead.
+	badClient := NewClient("tok", WithBaseURL("http://127.0.0.1:0"), WithLogger(logger))
+	badClient.SendSMSContext(context.Background(), batch)
+	if logBuf.Len() == 0 {
+		t.Error("expected logger to receive at least one diagnostic line")
+	}
+}
+
+func TestWithHTTPClient_Used(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	custom := &http.Client{}
+	c := NewClient("tok", WithBaseURL(server.URL), WithHTTPClient(custom))
+	if c.httpClient() != custom {
+		t.Error("expected httpClient() to return the custom *http.Client")
+	}
+}
+
+func TestSendSMSContext_SetsMessageIDsAndSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(SendResponse{
+			Status: 0,
+			Data: struct {
+				SubmitResults []struct {
+					MsID      string `json:"msid"`
+					MessageID int    `json:"messageID"`
+					Code      string `json:"code"`
+				} `json:"submitResults"`
+			}{
+				SubmitResults: []struct {
+					MsID      string `json:"msid"`
+					MessageID int    `json:"messageID"`
+					Code      string `json:"code"`
+				}{
+					{"79001234567", 1001, "OK"},
+					{"79007654321", 1002, "REJECTED"},
+				},
+			},
+		})
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{
+		{MsID: "79001234567", Message: "hi"},
+		{MsID: "79007654321", Message: "there"},
+	}}
+	if err := c.SendSMSContext(context.Background(), batch); err != nil {
+		t.Fatalf("SendSMSContext failed: %v", err)
+	}
+	if batch.Submits[0].MessageID != 1001 || batch.Submits[0].SendError {
+		t.Errorf("unexpected submit[0]: %+v", batch.Submits[0])
+	}
+	if batch.Submits[1].MessageID != 1002 || !batch.Submits[1].SendError {
+		t.Errorf("unexpected submit[1]: %+v", batch.Submits[1])
+	}
+}
+
+func TestSendSMSContext_HTTPStatusErrorIsStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	err := c.SendSMSContext(context.Background(), batch)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a *StatusError, got %v (%T)", err, err)
+	}
+	if statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", statusErr.StatusCode, http.StatusServiceUnavailable)
+	}
+}
+
+func TestGetSMSStatusesContext_HTTPStatusErrorIsStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", WithBaseURL(server.URL), withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	_, err := c.GetSMSStatusesContext(context.Background(), []int{1001})
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a *StatusError, got %v (%T)", err, err)
+	}
+	if statusErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want %d", statusErr.StatusCode, http.StatusBadGateway)
+	}
+}
+
+func TestGetSMSStatusesContext_Empty(t *testing.T) {
+	c := NewClient("tok")
+	statuses, err := c.GetSMSStatusesContext(context.Background(), []int{})
+	if err != nil {
+		t.Fatalf("GetSMSStatusesContext failed: %v", err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("expected no statuses, got %+v", statuses)
+	}
+}
+
+func TestGetSMSStatusContext_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(testStatusPayload{Code: 0, Data: []testStatusDataItem{}})
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	_, err := c.GetSMSStatusContext(context.Background(), 9999)
+	if err == nil {
+		t.Fatal("expected error for a message ID with no status")
+	}
+}
+
+func TestGetSMSStatusContext_Single(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(testStatusPayload{
+			Code: 0,
+			Data: []testStatusDataItem{
+				{MessageID: 1001, Statuses: []testStatusEntry{{"79001234567", StatusDelivered, 2}}},
+			},
+		})
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	status, err := c.GetSMSStatusContext(context.Background(), 1001)
+	if err != nil {
+		t.Fatalf("GetSMSStatusContext failed: %v", err)
+	}
+	if status.Status != StatusDelivered || status.Cost != 2 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestSendSMSContext_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := NewClient("tok", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+	if err := c.SendSMSContext(ctx, batch); err == nil {
+		t.Fatal("expected error for a cancelled context")
+	}
+}
+
+func TestClient_SendAndStatus_ImplementSenderInterface(t *testing.T) {
+	var c Sender = NewClient("tok")
+	_ = c
+}
@@ -0,0 +1,44 @@
+package smsmts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ExampleProviderSender is a skeleton showing how to adapt a second SMS
+// provider to the Sender interface so it can be used as a FailoverSender
+// fallback. It is not a real integration: fill in the request/response
+// mapping for the provider you're adding and drop the "TODO"s below.
+type ExampleProviderSender struct {
+	APIKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewExampleProviderSender creates a skeleton Sender for a second provider.
+func NewExampleProviderSender(apiKey, baseURL string) *ExampleProviderSender {
+	return &ExampleProviderSender{
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// Send should translate batch into the other provider's request format,
+// issue it, and set SendError/MessageID on batch.Submits the same way
+// Client.Send does.
+func (e *ExampleProviderSender) Send(ctx context.Context, batch *SubmitBatch) error {
+	// TODO: build the provider-specific request from batch.Submits,
+	// issue it with e.HTTPClient using ctx, and populate
+	// batch.Submits[i].MessageID/SendError from the response.
+	return fmt.Errorf("smsmts: ExampleProviderSender.Send: not implemented")
+}
+
+// Status should translate messageIDs into the other provider's status
+// query and map its response back to []MessageStatus.
+func (e *ExampleProviderSender) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	// TODO: query the provider's status endpoint and map each result to
+	// a MessageStatus using the Status*/IsFinalStatus conventions.
+	return nil, fmt.Errorf("smsmts: ExampleProviderSender.Status: not implemented")
+}
@@ -2,14 +2,8 @@
 package smsmts
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"strconv"
-	"strings"
-	"time"
 )
 
 // https://support.mts.ru/mts_marketolog/rassilki-po-svoei-baze-pro-i-api-k-nim/dokumentatsiya-rest-api
@@ -22,6 +16,7 @@ var (
 // Constants for statuses
 const (
 	StatusNotSent      = "NotSent"
+	StatusPending      = "Pending"
 	StatusSent         = "Sent"
 	StatusSending      = "Sending"
 	StatusDelivered    = "Delivered"
@@ -83,139 +78,31 @@ type SubmitMsg struct {
 type SubmitBatch struct {
 	Submits []SubmitMsg `json:"submits"`
 	Naming  string      `json:"naming"`
+
+	// BatchID, if set, is used as the Idempotency-Key for SendSMSWithRetry
+	// instead of a hash of the payload. It is not sent to the MTS API.
+	BatchID string `json:"-"`
 }
 
-// SendSMS sends a batch of SMS messages
+// SendSMS sends a batch of SMS messages. It is a thin wrapper around a
+// Client built from the package-level SendMessageEndpoint/QueryTimeoutSec
+// settings, kept for backward compatibility; new code should construct a
+// Client directly (see NewClient) and call SendSMSContext.
 func SendSMS(batch *SubmitBatch, token string) error {
-	payload, err := json.Marshal(batch)
-	if err != nil {
-		return fmt.Errorf("json.Marshal(): %v", err)
-	}
-
-	client := &http.Client{
-		Timeout: time.Duration(QueryTimeoutSec) * time.Second,
-	}
-	req, err := http.NewRequest(
-		"POST",
-		SendMessageEndpoint,
-		bytes.NewBuffer(payload),
-	)
-	if err != nil {
-		return fmt.Errorf("NewRequest(): %v", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("client.Do(): %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http status: %d with token: %s", resp.StatusCode, token)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("io.ReadAll(): %v", err)
-	}
-
-	var respStruct SendResponse
-	if err := json.Unmarshal(body, &respStruct); err != nil {
-		return fmt.Errorf("json.Unmarshal(): %v", err)
-	}
-
-	// iterate submit result and set message data
-	for _, sbRes := range respStruct.Data.SubmitResults {
-		// set result and message ID
-		// find by MsID (tel)
-		for i := range batch.Submits {
-			if batch.Submits[i].MsID == sbRes.MsID {
-				batch.Submits[i].MessageID = sbRes.MessageID
-				if sbRes.Code != "OK" {
-					batch.Submits[i].SendError = true
-				}
-				break
-			}
-		}
-	}
-
-	if respStruct.Status != 0 {
-		// some error
-		return fmt.Errorf("error: %s", respStruct.Description)
-	}
-
-	return nil
+	c := NewClient(token, WithBaseURL(SendMessageEndpoint), withStatusURLTempl(MessageStatusEndpointTempl))
+	return c.SendSMSContext(context.Background(), batch)
 }
 
+// GetSMSStatuses fetches statuses for the given message IDs. It is a thin
+// wrapper around a Client built from the package-level
+// MessageStatusEndpointTempl/QueryTimeoutSec settings, kept for backward
+// compatibility; new code should construct a Client directly (see NewClient)
+// and call GetSMSStatusesContext.
 func GetSMSStatuses(messageIDs []int, token string) ([]MessageStatus, error) {
-	if len(messageIDs) == 0 {
-		return []MessageStatus{}, nil
-	}
-
-	// Convert int IDs to strings for URL
-	idStrs := make([]string, len(messageIDs))
-	for i, id := range messageIDs {
-		idStrs[i] = strconv.Itoa(id)
-	}
-	idsParam := strings.Join(idStrs, ",")
-
-	client := &http.Client{
-		Timeout: time.Duration(QueryTimeoutSec) * time.Second,
-	}
-	req, err := http.NewRequest(
-		"GET",
-		fmt.Sprintf(MessageStatusEndpointTempl, idsParam),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("NewRequest(): %v", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("client.Do(): %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http status: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("io.ReadAll(): %v", err)
-	}
-
-	var respStruct StatResponse
-	if err := json.Unmarshal(body, &respStruct); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal(): %v, body: %s", err, string(body))
-	}
-	
-	// Check response code (0 means success in this API)
-	if respStruct.Code != 0 {
-		return nil, fmt.Errorf("API error: %s", respStruct.Description)
-	}
-
-	// Map statuses to MessageStatus objects
-	var allStatuses []MessageStatus
-	for _, dataItem := range respStruct.Data {
-		for _, stRes := range dataItem.Statuses {
-			allStatuses = append(allStatuses, MessageStatus{
-				MessageID: strconv.Itoa(dataItem.MessageID),
-				MsID:      stRes.MsID,
-				Status:    stRes.Status,
-				Cost:      stRes.Cost,
-			})
-		}
-	}
-
-	return allStatuses, nil
+	c := NewClient(token, WithBaseURL(SendMessageEndpoint), withStatusURLTempl(MessageStatusEndpointTempl))
+	return c.GetSMSStatusesContext(context.Background(), messageIDs)
 }
+
 // GetSMSStatus returns status for a single message ID
 func GetSMSStatus(messageID int, token string) (*MessageStatus, error) {
 	statuses, err := GetSMSStatuses([]int{messageID}, token)
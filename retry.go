@@ -0,0 +1,195 @@
+package smsmts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how SendSMSWithRetry retries a failed send.
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+
+	// JitterFraction adds up to JitterFraction*delay of random jitter to
+	// each backoff, so that many clients retrying at once don't line up.
+	JitterFraction float64
+
+	// RetryableStatusCodes lists HTTP statuses that should be retried.
+	// Transport-level errors (no response at all) are always retried.
+	RetryableStatusCodes map[int]struct{}
+}
+
+// DefaultRetryPolicy returns the policy used when SendSMSWithRetry is called
+// with a nil policy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		JitterFraction: 0.2,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusRequestTimeout:      {},
+			http.StatusTooManyRequests:     {},
+			http.StatusInternalServerError: {},
+			http.StatusBadGateway:          {},
+			http.StatusServiceUnavailable:  {},
+			http.StatusGatewayTimeout:      {},
+		},
+	}
+}
+
+func (p RetryPolicy) isRetryableStatus(statusCode int) bool {
+	if p.RetryableStatusCodes == nil {
+		return false
+	}
+	_, ok := p.RetryableStatusCodes[statusCode]
+	return ok
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff) * pow(p.Multiplier, attempt)
+	if max := float64(p.MaxBackoff); max > 0 && delay > max {
+		delay = max
+	}
+	if p.JitterFraction > 0 {
+		delay += delay * p.JitterFraction * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+func pow(base float64, exp int) float64 {
+	result := 1.0
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
+
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// SendAttemptError wraps the error from one failed SendSMSWithRetry attempt,
+// recording which attempt it was so callers can inspect the full history.
+type SendAttemptError struct {
+	Attempt int
+	Err     error
+}
+
+func (e *SendAttemptError) Error() string {
+	return fmt.Sprintf("attempt %d: %v", e.Attempt, e.Err)
+}
+
+func (e *SendAttemptError) Unwrap() error { return e.Err }
+
+// SendRetryError is returned by SendSMSWithRetry when every attempt failed.
+// It carries the error from every attempt, most recent last. If ctx is
+// cancelled while waiting to retry, a final entry holding ctx.Err() is
+// appended for the attempt that was not made.
+type SendRetryError struct {
+	Attempts []*SendAttemptError
+}
+
+func (e *SendRetryError) Error() string {
+	if len(e.Attempts) == 0 {
+		return "smsmts: all retry attempts failed"
+	}
+	return fmt.Sprintf("smsmts: all %d attempts failed, last error: %v", len(e.Attempts), e.Attempts[len(e.Attempts)-1].Err)
+}
+
+func (e *SendRetryError) Unwrap() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1]
+}
+
+// idempotencyKey derives a stable key for a batch so that SendSMSWithRetry
+// can safely resend it without risking duplicate delivery: a caller-supplied
+// BatchID is used verbatim, otherwise the key is the SHA-256 of the batch's
+// JSON payload.
+func idempotencyKey(batch *SubmitBatch) (string, error) {
+	if batch.BatchID != "" {
+		return batch.BatchID, nil
+	}
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal(): %v", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SendSMSWithRetry sends batch, retrying on transport errors and on the
+// status codes listed in policy.RetryableStatusCodes, with exponential
+// backoff and jitter between attempts. Every attempt (including retries)
+// carries the same Idempotency-Key header so the API can de-duplicate a
+// submission that the client retried after an ambiguous failure.
+//
+// If policy is nil, DefaultRetryPolicy is used. Pass a non-nil policy with
+// MaxRetries: 0 to send once without retrying.
+func (c *Client) SendSMSWithRetry(ctx context.Context, batch *SubmitBatch, policy *RetryPolicy) error {
+	p := DefaultRetryPolicy()
+	if policy != nil {
+		p = *policy
+	}
+
+	key, err := idempotencyKey(batch)
+	if err != nil {
+		return err
+	}
+
+	retryErr := &SendRetryError{}
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
+		statusCode, retryAfter, err := c.sendSMSOnce(ctx, batch, key)
+		if err == nil {
+			return nil
+		}
+		retryErr.Attempts = append(retryErr.Attempts, &SendAttemptError{Attempt: attempt, Err: err})
+
+		if attempt == p.MaxRetries {
+			break
+		}
+		if statusCode != 0 && !p.isRetryableStatus(statusCode) {
+			break
+		}
+
+		delay := p.backoff(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		c.logf("smsmts: SendSMSWithRetry: attempt %d failed: %v, retrying in %s", attempt, err, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			retryErr.Attempts = append(retryErr.Attempts, &SendAttemptError{Attempt: attempt + 1, Err: ctx.Err()})
+			return retryErr
+		case <-timer.C:
+		}
+	}
+
+	return retryErr
+}
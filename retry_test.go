@@ -0,0 +1,194 @@
+package smsmts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func sendResponseBody(code string) []byte {
+	body, _ := json.Marshal(SendResponse{
+		Status: 0,
+		Data: struct {
+			SubmitResults []struct {
+				MsID      string `json:"msid"`
+				MessageID int    `json:"messageID"`
+				Code      string `json:"code"`
+			} `json:"submitResults"`
+		}{
+			SubmitResults: []struct {
+				MsID      string `json:"msid"`
+				MessageID int    `json:"messageID"`
+				Code      string `json:"code"`
+			}{
+				{"79001234567", 1001, code},
+			},
+		},
+	})
+	return body
+}
+
+func TestSendSMSWithRetry_SucceedsAfterRetryableFailures(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	err := c.SendSMSWithRetry(context.Background(), batch, &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusServiceUnavailable: {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendSMSWithRetry failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if batch.Submits[0].MessageID != 1001 {
+		t.Errorf("MessageID not set: %+v", batch.Submits[0])
+	}
+}
+
+func TestSendSMSWithRetry_StopsOnNonRetryableStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	err := c.SendSMSWithRetry(context.Background(), batch, &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusServiceUnavailable: {},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected a single attempt for a non-retryable status, got %d", got)
+	}
+}
+
+func TestSendSMSWithRetry_ExplicitZeroMeansNoRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	err := c.SendSMSWithRetry(context.Background(), batch, &RetryPolicy{
+		MaxRetries: 0,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusServiceUnavailable: {},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("an explicit MaxRetries:0 must send exactly once, got %d attempts", got)
+	}
+}
+
+func TestSendSMSWithRetry_NilPolicyUsesDefault(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(atomic.AddInt32(&attempts, 1)) <= DefaultRetryPolicy().MaxRetries {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	if err := c.SendSMSWithRetry(context.Background(), batch, nil); err != nil {
+		t.Fatalf("SendSMSWithRetry failed: %v", err)
+	}
+}
+
+func TestSendSMSWithRetry_IdempotencyKeyStableAcrossAttempts(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(sendResponseBody("OK"))
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{
+		BatchID: "fixed-batch-id",
+		Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}},
+	}
+
+	err := c.SendSMSWithRetry(context.Background(), batch, &RetryPolicy{
+		MaxRetries:     1,
+		InitialBackoff: time.Millisecond,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusServiceUnavailable: {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendSMSWithRetry failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "fixed-batch-id" || keys[1] != "fixed-batch-id" {
+		t.Errorf("expected stable Idempotency-Key across attempts, got %v", keys)
+	}
+}
+
+func TestSendSMSWithRetry_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClient("token", WithBaseURL(server.URL))
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.SendSMSWithRetry(ctx, batch, &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 50 * time.Millisecond,
+		RetryableStatusCodes: map[int]struct{}{
+			http.StatusServiceUnavailable: {},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,378 @@
+package smsmts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sender is the behavior SendSMS/GetSMSStatuses are built on. Extracting it
+// lets callers swap the real MTS client for a test double (MemorySender) or
+// chain several providers together (FailoverSender) without changing call
+// sites.
+type Sender interface {
+	Send(ctx context.Context, batch *SubmitBatch) error
+	Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error)
+}
+
+// Send implements Sender by delegating to SendSMSContext.
+func (c *Client) Send(ctx context.Context, batch *SubmitBatch) error {
+	return c.SendSMSContext(ctx, batch)
+}
+
+// Status implements Sender by delegating to GetSMSStatusesContext.
+func (c *Client) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	return c.GetSMSStatusesContext(ctx, messageIDs)
+}
+
+// MemorySender is an in-memory Sender that never calls out to the network.
+// It is meant for tests and CI: it records every submitted batch and
+// simulates delivery outcomes according to Delay/FailureRate/Cost, so
+// callers can exercise send+poll (or StatusTracker) code paths without
+// hitting the real MTS API.
+type MemorySender struct {
+	// Delay is how long a message stays in StatusSending before it
+	// transitions to its final status.
+	Delay time.Duration
+	// FailureRate is the probability (0..1) that a message ends up
+	// StatusNotDelivered instead of StatusDelivered.
+	FailureRate float64
+	// Cost is the cost reported for each delivered message.
+	Cost float64
+
+	mu       sync.Mutex
+	nextID   int
+	Batches  []*SubmitBatch
+	messages map[int]*memoryMessage
+}
+
+type memoryMessage struct {
+	msID      string
+	submitted time.Time
+	final     string
+	cost      float64
+}
+
+// NewMemorySender creates a MemorySender with the given options.
+func NewMemorySender(opts ...MemorySenderOption) *MemorySender {
+	m := &MemorySender{
+		messages: make(map[int]*memoryMessage),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// MemorySenderOption configures a MemorySender constructed via NewMemorySender.
+type MemorySenderOption func(*MemorySender)
+
+// WithMemoryDelay sets how long a message simulates StatusSending for.
+func WithMemoryDelay(d time.Duration) MemorySenderOption {
+	return func(m *MemorySender) { m.Delay = d }
+}
+
+// WithMemoryFailureRate sets the fraction of messages that end up failed.
+func WithMemoryFailureRate(rate float64) MemorySenderOption {
+	return func(m *MemorySender) { m.FailureRate = rate }
+}
+
+// WithMemoryCost sets the cost reported for delivered messages.
+func WithMemoryCost(cost float64) MemorySenderOption {
+	return func(m *MemorySender) { m.Cost = cost }
+}
+
+// Send records batch and assigns each submit a MessageID, as the real API
+// would.
+func (m *MemorySender) Send(ctx context.Context, batch *SubmitBatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Batches = append(m.Batches, batch)
+
+	now := time.Now()
+	for i := range batch.Submits {
+		m.nextID++
+		id := m.nextID
+		batch.Submits[i].MessageID = id
+
+		final := StatusDelivered
+		cost := m.Cost
+		if rand.Float64() < m.FailureRate {
+			final = StatusNotDelivered
+			cost = 0
+		}
+		m.messages[id] = &memoryMessage{
+			msID:      batch.Submits[i].MsID,
+			submitted: now,
+			final:     final,
+			cost:      cost,
+		}
+	}
+	return nil
+}
+
+// Status reports StatusSending for messages still within Delay and their
+// simulated final status afterwards.
+func (m *MemorySender) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]MessageStatus, 0, len(messageIDs))
+	for _, id := range messageIDs {
+		msg, ok := m.messages[id]
+		if !ok {
+			continue
+		}
+		status := msg.final
+		cost := msg.cost
+		if time.Since(msg.submitted) < m.Delay {
+			status = StatusSending
+			cost = 0
+		}
+		statuses = append(statuses, MessageStatus{
+			MessageID: fmt.Sprintf("%d", id),
+			MsID:      msg.msID,
+			Status:    status,
+			Cost:      cost,
+		})
+	}
+	return statuses, nil
+}
+
+// FailoverSender wraps several Senders and tries them in order, falling
+// through to the next one when a Send/Status call fails with a retryable
+// error. This lets a caller configure a secondary SMS provider that is used
+// transparently when the primary is down, without changing call sites.
+//
+// FailoverSender remembers which sender actually accepted each message, so
+// a later Status call queries that sender first instead of whichever one
+// Weights happens to pick that time; see Status.
+type FailoverSender struct {
+	Senders []Sender
+
+	// Weights, if non-nil, must be the same length as Senders. Each Send
+	// call (and any Status call for message IDs with no recorded owner)
+	// picks its starting sender by weighted random choice and then falls
+	// through the remaining senders in order; this spreads load across
+	// providers instead of always preferring Senders[0].
+	Weights []int
+
+	// RetryPolicy.RetryableStatusCodes decides whether a StatusError from
+	// one sender is worth falling through to the next one for, the same
+	// way SendSMSWithRetry decides whether to retry. Errors that aren't a
+	// *StatusError (e.g. transport failures) are always treated as worth
+	// failing over. Defaults to DefaultRetryPolicy() if RetryableStatusCodes
+	// is nil.
+	RetryPolicy RetryPolicy
+
+	mu    sync.Mutex
+	owner map[int]int // MessageID -> index into Senders that accepted it
+}
+
+// NewFailoverSender creates a FailoverSender that tries senders in order.
+func NewFailoverSender(senders ...Sender) *FailoverSender {
+	return &FailoverSender{Senders: senders, owner: make(map[int]int)}
+}
+
+func (f *FailoverSender) order() []int {
+	n := len(f.Senders)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	if len(f.Weights) != n {
+		return idx
+	}
+
+	start := weightedChoice(f.Weights)
+	ordered := make([]int, 0, n)
+	ordered = append(ordered, start)
+	for _, i := range idx {
+		if i != start {
+			ordered = append(ordered, i)
+		}
+	}
+	return ordered
+}
+
+func weightedChoice(weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return 0
+	}
+	r := rand.Intn(total)
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return len(weights) - 1
+}
+
+// isRetryable reports whether err is worth failing over to another sender
+// for. A *StatusError is judged by RetryPolicy.RetryableStatusCodes, same as
+// SendSMSWithRetry; any other error (transport failures, timeouts) is always
+// considered worth trying another provider for.
+func (f *FailoverSender) isRetryable(err error) bool {
+	policy := f.RetryPolicy
+	if policy.RetryableStatusCodes == nil {
+		policy = DefaultRetryPolicy()
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return policy.isRetryableStatus(statusErr.StatusCode)
+	}
+	return true
+}
+
+// recordOwner remembers, for every submit that got a MessageID, which
+// sender index accepted it, so Status can go back to the sender that
+// actually has the data instead of whichever one Weights picks next time.
+func (f *FailoverSender) recordOwner(idx int, batch *SubmitBatch) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.owner == nil {
+		f.owner = make(map[int]int)
+	}
+	for _, m := range batch.Submits {
+		if m.MessageID == 0 {
+			continue
+		}
+		f.owner[m.MessageID] = idx
+	}
+}
+
+// Send tries each sender in turn, stopping at the first success and
+// recording it as the owner of every message in batch. It only falls
+// through to the next sender when the failure is retryable (see
+// isRetryable); a permanent/validation error from the primary is returned
+// immediately instead of being swallowed.
+func (f *FailoverSender) Send(ctx context.Context, batch *SubmitBatch) error {
+	var lastErr error
+	for _, i := range f.order() {
+		err := f.Senders[i].Send(ctx, batch)
+		if err == nil {
+			f.recordOwner(i, batch)
+			return nil
+		}
+		lastErr = err
+		if !f.isRetryable(err) {
+			break
+		}
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("smsmts: FailoverSender: no senders configured")
+	}
+	return lastErr
+}
+
+// Status resolves messageIDs against the senders that are known to own
+// them (see Send), falling back to the full sender order for any IDs with
+// no recorded owner. A sender returning fewer statuses than requested is
+// not treated as a complete success: the still-missing IDs are retried
+// against the remaining senders in order, so a sender that simply has no
+// record of an ID can't masquerade as having successfully answered for it.
+func (f *FailoverSender) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	f.mu.Lock()
+	groups := make(map[int][]int)
+	var unowned []int
+	for _, id := range messageIDs {
+		if idx, ok := f.owner[id]; ok {
+			groups[idx] = append(groups[idx], id)
+		} else {
+			unowned = append(unowned, id)
+		}
+	}
+	f.mu.Unlock()
+
+	var all []MessageStatus
+	var lastErr error
+
+	for idx, ids := range groups {
+		order := append([]int{idx}, f.orderExcluding(idx)...)
+		statuses, err := f.resolveIDs(ctx, ids, order)
+		all = append(all, statuses...)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	if len(unowned) > 0 {
+		statuses, err := f.resolveIDs(ctx, unowned, f.order())
+		all = append(all, statuses...)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	if len(all) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	if len(all) == 0 && len(f.Senders) == 0 {
+		return nil, fmt.Errorf("smsmts: FailoverSender: no senders configured")
+	}
+	return all, nil
+}
+
+// resolveIDs queries the senders in order for ids, shrinking the
+// still-missing set as responses come back, and stops trying further
+// senders for an ID subset as soon as a sender returns a non-retryable
+// error for it.
+func (f *FailoverSender) resolveIDs(ctx context.Context, ids []int, order []int) ([]MessageStatus, error) {
+	remaining := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		remaining[id] = struct{}{}
+	}
+
+	var all []MessageStatus
+	var lastErr error
+	for _, idx := range order {
+		if len(remaining) == 0 {
+			break
+		}
+		want := make([]int, 0, len(remaining))
+		for id := range remaining {
+			want = append(want, id)
+		}
+
+		statuses, err := f.Senders[idx].Status(ctx, want)
+		if err != nil {
+			lastErr = err
+			if !f.isRetryable(err) {
+				break
+			}
+			continue
+		}
+		for _, s := range statuses {
+			all = append(all, s)
+			if id, convErr := strconv.Atoi(s.MessageID); convErr == nil {
+				delete(remaining, id)
+			}
+		}
+	}
+
+	if len(all) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return all, nil
+}
+
+func (f *FailoverSender) orderExcluding(skip int) []int {
+	order := f.order()
+	out := make([]int, 0, len(order))
+	for _, idx := range order {
+		if idx != skip {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
@@ -0,0 +1,201 @@
+package smsmts
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSender is a minimal Sender test double with per-call counters, used
+// to assert which underlying sender FailoverSender actually talked to.
+type fakeSender struct {
+	sendErr      error
+	sendFn       func(*SubmitBatch)
+	statusResult []MessageStatus
+	statusErr    error
+
+	sendCalls   int32
+	statusCalls int32
+}
+
+func (f *fakeSender) Send(ctx context.Context, batch *SubmitBatch) error {
+	atomic.AddInt32(&f.sendCalls, 1)
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	if f.sendFn != nil {
+		f.sendFn(batch)
+	}
+	return nil
+}
+
+func (f *fakeSender) Status(ctx context.Context, messageIDs []int) ([]MessageStatus, error) {
+	atomic.AddInt32(&f.statusCalls, 1)
+	if f.statusErr != nil {
+		return nil, f.statusErr
+	}
+	return f.statusResult, nil
+}
+
+func TestMemorySender_SendAndStatus(t *testing.T) {
+	m := NewMemorySender(WithMemoryFailureRate(0), WithMemoryCost(1.5))
+
+	batch := &SubmitBatch{Submits: []SubmitMsg{
+		{MsID: "79001234567", Message: "hi"},
+		{MsID: "79007654321", Message: "there"},
+	}}
+	if err := m.Send(context.Background(), batch); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if batch.Submits[0].MessageID == 0 || batch.Submits[1].MessageID == 0 {
+		t.Fatalf("MessageIDs not assigned: %+v", batch.Submits)
+	}
+	if batch.Submits[0].MessageID == batch.Submits[1].MessageID {
+		t.Fatalf("expected distinct MessageIDs, got %+v", batch.Submits)
+	}
+
+	ids := []int{batch.Submits[0].MessageID, batch.Submits[1].MessageID}
+	statuses, err := m.Status(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 statuses, got %d", len(statuses))
+	}
+	for _, s := range statuses {
+		if s.Status != StatusDelivered {
+			t.Errorf("expected StatusDelivered with FailureRate 0, got %q", s.Status)
+		}
+		if s.Cost != 1.5 {
+			t.Errorf("expected cost 1.5, got %v", s.Cost)
+		}
+	}
+}
+
+func TestMemorySender_DelayReportsSendingUntilElapsed(t *testing.T) {
+	m := NewMemorySender(WithMemoryDelay(50*time.Millisecond), WithMemoryFailureRate(0))
+
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567", Message: "hi"}}}
+	if err := m.Send(context.Background(), batch); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	id := batch.Submits[0].MessageID
+
+	statuses, err := m.Status(context.Background(), []int{id})
+	if err != nil || len(statuses) != 1 {
+		t.Fatalf("Status failed: %v, %v", statuses, err)
+	}
+	if statuses[0].Status != StatusSending {
+		t.Errorf("expected StatusSending before Delay elapses, got %q", statuses[0].Status)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	statuses, err = m.Status(context.Background(), []int{id})
+	if err != nil || len(statuses) != 1 {
+		t.Fatalf("Status failed: %v, %v", statuses, err)
+	}
+	if statuses[0].Status != StatusDelivered {
+		t.Errorf("expected StatusDelivered after Delay elapses, got %q", statuses[0].Status)
+	}
+}
+
+func TestMemorySender_StatusUnknownIDIsEmpty(t *testing.T) {
+	m := NewMemorySender()
+	statuses, err := m.Status(context.Background(), []int{9999})
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("expected no statuses for an unknown ID, got %+v", statuses)
+	}
+}
+
+func TestFailoverSender_SendFallsOverOnRetryableError(t *testing.T) {
+	primary := &fakeSender{sendErr: &StatusError{StatusCode: 503, Err: fmt.Errorf("unavailable")}}
+	secondary := &fakeSender{sendFn: func(b *SubmitBatch) { b.Submits[0].MessageID = 42 }}
+
+	fs := NewFailoverSender(primary, secondary)
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567"}}}
+
+	if err := fs.Send(context.Background(), batch); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if batch.Submits[0].MessageID != 42 {
+		t.Errorf("expected secondary's MessageID to be set, got %+v", batch.Submits[0])
+	}
+	if atomic.LoadInt32(&secondary.sendCalls) != 1 {
+		t.Errorf("expected secondary to be tried once, got %d", secondary.sendCalls)
+	}
+}
+
+func TestFailoverSender_SendStopsOnNonRetryableError(t *testing.T) {
+	primaryErr := &StatusError{StatusCode: 400, Err: fmt.Errorf("bad request")}
+	primary := &fakeSender{sendErr: primaryErr}
+	secondary := &fakeSender{}
+
+	fs := NewFailoverSender(primary, secondary)
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567"}}}
+
+	err := fs.Send(context.Background(), batch)
+	if err != primaryErr {
+		t.Fatalf("expected the primary's non-retryable error back, got %v", err)
+	}
+	if atomic.LoadInt32(&secondary.sendCalls) != 0 {
+		t.Errorf("expected secondary to never be tried for a non-retryable error, got %d calls", secondary.sendCalls)
+	}
+}
+
+// TestFailoverSender_StatusUsesRecordedOwner reproduces the bug from code
+// review: querying Status through a FailoverSender must go back to the
+// sender that actually accepted the message, not whichever sender Weights
+// happens to pick that call; a sender with no record of an ID must not be
+// able to masquerade as a successful empty answer.
+func TestFailoverSender_StatusUsesRecordedOwner(t *testing.T) {
+	primary := &fakeSender{
+		sendFn: func(b *SubmitBatch) { b.Submits[0].MessageID = 1001 },
+		statusResult: []MessageStatus{
+			{MessageID: "1001", MsID: "79001234567", Status: StatusDelivered},
+		},
+	}
+	secondary := &fakeSender{} // knows nothing: always returns (nil, nil)
+
+	fs := NewFailoverSender(primary, secondary)
+	batch := &SubmitBatch{Submits: []SubmitMsg{{MsID: "79001234567"}}}
+	if err := fs.Send(context.Background(), batch); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	// Bias heavily toward secondary for any call that doesn't already know
+	// its owner; a FailoverSender that doesn't track ownership would often
+	// ask secondary first and wrongly return its empty answer.
+	fs.Weights = []int{1, 99}
+
+	for i := 0; i < 20; i++ {
+		statuses, err := fs.Status(context.Background(), []int{1001})
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+		if len(statuses) != 1 || statuses[0].Status != StatusDelivered {
+			t.Fatalf("run %d: expected the real status from primary, got %+v", i, statuses)
+		}
+	}
+}
+
+func TestFailoverSender_StatusFallsThroughOnRetryableError(t *testing.T) {
+	primary := &fakeSender{statusErr: &StatusError{StatusCode: 503, Err: fmt.Errorf("unavailable")}}
+	secondary := &fakeSender{statusResult: []MessageStatus{
+		{MessageID: "1001", MsID: "79001234567", Status: StatusSent},
+	}}
+
+	fs := NewFailoverSender(primary, secondary)
+	statuses, err := fs.Status(context.Background(), []int{1001})
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != StatusSent {
+		t.Fatalf("expected fallback status from secondary, got %+v", statuses)
+	}
+}
@@ -9,6 +9,27 @@ import (
 	"time"
 )
 
+// testStatusPayload mirrors the wire shape GetSMSStatuses/GetSMSStatus
+// parses into StatResponse, kept separate from that type (whose Data field
+// is an anonymous struct) so tests can build a response body without
+// repeating its full field list.
+type testStatusPayload struct {
+	Code        int                  `json:"code"`
+	Description string               `json:"description"`
+	Data        []testStatusDataItem `json:"data"`
+}
+
+type testStatusDataItem struct {
+	MessageID int               `json:"messageID"`
+	Statuses  []testStatusEntry `json:"statuses"`
+}
+
+type testStatusEntry struct {
+	MsID   string  `json:"msid"`
+	Status string  `json:"status"`
+	Cost   float64 `json:"cost"`
+}
+
 func TestSendSMS_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -105,25 +126,16 @@ func TestGetSMSStatuses_Success(t *testing.T) {
 			t.Errorf("Invalid messageIDs: %s", r.URL.Query().Get("messageIDs"))
 		}
 
-		response := StatResponse{
-			Status:      0,
+		response := testStatusPayload{
+			Code:        0,
 			Description: "Success",
-			Data: struct {
-				MessageID int `json:"messageID"`
-				Statuses  []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				} `json:"statuses"`
-			}{
-				MessageID: 1001,
-				Statuses: []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				}{
-					{"79001234567", StatusDelivered, 1.5},
-					{"79007654321", StatusPending, 0},
+			Data: []testStatusDataItem{
+				{
+					MessageID: 1001,
+					Statuses: []testStatusEntry{
+						{"79001234567", StatusDelivered, 1.5},
+						{"79007654321", StatusPending, 0},
+					},
 				},
 			},
 		}
@@ -163,24 +175,15 @@ func TestGetSMSStatuses_Empty(t *testing.T) {
 
 func TestGetSMSStatus_Single(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := StatResponse{
-			Status:      0,
+		response := testStatusPayload{
+			Code:        0,
 			Description: "Success",
-			Data: struct {
-				MessageID int `json:"messageID"`
-				Statuses  []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				} `json:"statuses"`
-			}{
-				MessageID: 1001,
-				Statuses: []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				}{
-					{"79001234567", StatusSent, 1.5},
+			Data: []testStatusDataItem{
+				{
+					MessageID: 1001,
+					Statuses: []testStatusEntry{
+						{"79001234567", StatusSent, 1.5},
+					},
 				},
 			},
 		}
@@ -206,23 +209,14 @@ func TestGetSMSStatus_Single(t *testing.T) {
 
 func TestGetSMSStatus_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := StatResponse{
-			Status:      0,
+		response := testStatusPayload{
+			Code:        0,
 			Description: "Success",
-			Data: struct {
-				MessageID int `json:"messageID"`
-				Statuses  []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				} `json:"statuses"`
-			}{
-				MessageID: 9999,
-				Statuses:  []struct {
-					MsID   string  `json:"msid"`
-					Status string  `json:"status"`
-					Cost   float64 `json:"cost"`
-				}{},
+			Data: []testStatusDataItem{
+				{
+					MessageID: 9999,
+					Statuses:  []testStatusEntry{},
+				},
 			},
 		}
 
@@ -0,0 +1,164 @@
+package smsmts
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// StatusTracker polls GetSMSStatusesContext until every tracked message
+// reaches a final status (per IsFinalStatus) or the caller's context is
+// cancelled, so callers don't have to hand-roll the "send, then wait for
+// delivery receipts" loop themselves.
+type StatusTracker struct {
+	client *Client
+
+	PollInterval      time.Duration
+	MaxInterval       time.Duration
+	BackoffMultiplier float64
+	Timeout           time.Duration
+}
+
+// TrackerOption configures a StatusTracker constructed via NewStatusTracker.
+type TrackerOption func(*StatusTracker)
+
+// WithPollInterval sets the delay before the first re-poll.
+func WithPollInterval(d time.Duration) TrackerOption {
+	return func(t *StatusTracker) { t.PollInterval = d }
+}
+
+// WithMaxInterval caps how long the poll interval may grow to.
+func WithMaxInterval(d time.Duration) TrackerOption {
+	return func(t *StatusTracker) { t.MaxInterval = d }
+}
+
+// WithBackoffMultiplier sets the factor the poll interval is multiplied by
+// after each poll that still has non-final messages.
+func WithBackoffMultiplier(m float64) TrackerOption {
+	return func(t *StatusTracker) { t.BackoffMultiplier = m }
+}
+
+// WithTrackerTimeout bounds the total time WaitAll/Subscribe will track for,
+// independent of any deadline already set on the context passed in.
+func WithTrackerTimeout(d time.Duration) TrackerOption {
+	return func(t *StatusTracker) { t.Timeout = d }
+}
+
+// NewStatusTracker creates a StatusTracker that queries statuses through c.
+func NewStatusTracker(c *Client, opts ...TrackerOption) *StatusTracker {
+	t := &StatusTracker{
+		client:            c,
+		PollInterval:      2 * time.Second,
+		MaxInterval:       30 * time.Second,
+		BackoffMultiplier: 1.5,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WaitAll blocks until every message in ids reaches a final status, ctx is
+// cancelled, or the tracker's Timeout elapses, and returns the last known
+// status of each message seen.
+func (t *StatusTracker) WaitAll(ctx context.Context, ids []int) (map[int]MessageStatus, error) {
+	ch, err := t.Subscribe(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]MessageStatus, len(ids))
+	for status := range ch {
+		id, err := strconv.Atoi(status.MessageID)
+		if err != nil {
+			continue
+		}
+		result[id] = status
+	}
+	return result, nil
+}
+
+// Subscribe starts polling statuses for ids in the background and returns a
+// channel on which each distinct status transition is emitted exactly once.
+// The channel is closed once every message reaches a final status, ctx is
+// cancelled, or the tracker's Timeout elapses.
+func (t *StatusTracker) Subscribe(ctx context.Context, ids []int) (<-chan MessageStatus, error) {
+	if len(ids) == 0 {
+		ch := make(chan MessageStatus)
+		close(ch)
+		return ch, nil
+	}
+
+	cancel := func() {}
+	if t.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+	}
+
+	ch := make(chan MessageStatus)
+	go func() {
+		defer cancel()
+		t.run(ctx, ids, ch)
+	}()
+	return ch, nil
+}
+
+func (t *StatusTracker) run(ctx context.Context, ids []int, out chan<- MessageStatus) {
+	defer close(out)
+
+	pending := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		pending[id] = struct{}{}
+	}
+	last := make(map[int]string, len(ids))
+
+	interval := t.PollInterval
+	for {
+		pendingIDs := make([]int, 0, len(pending))
+		for id := range pending {
+			pendingIDs = append(pendingIDs, id)
+		}
+
+		statuses, err := t.client.GetSMSStatusesContext(ctx, pendingIDs)
+		if err != nil {
+			t.client.logf("smsmts: StatusTracker: GetSMSStatusesContext(): %v", err)
+		} else {
+			for _, status := range statuses {
+				id, err := strconv.Atoi(status.MessageID)
+				if err != nil {
+					continue
+				}
+				if last[id] == status.Status {
+					continue
+				}
+				last[id] = status.Status
+
+				select {
+				case out <- status:
+				case <-ctx.Done():
+					return
+				}
+
+				if IsFinalStatus(status.Status) {
+					delete(pending, id)
+				}
+			}
+		}
+
+		if len(pending) == 0 {
+			return
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * t.BackoffMultiplier)
+		if t.MaxInterval > 0 && interval > t.MaxInterval {
+			interval = t.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package smsmts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func statusServer(t *testing.T, statusesByCall func(call int) []testStatusEntry) *httptest.Server {
+	t.Helper()
+	var call int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&call, 1))
+		body, _ := json.Marshal(testStatusPayload{
+			Code: 0,
+			Data: []testStatusDataItem{
+				{MessageID: 1001, Statuses: statusesByCall(n)},
+			},
+		})
+		w.Write(body)
+	}))
+}
+
+func TestStatusTracker_Subscribe_EmptyIDsClosesImmediately(t *testing.T) {
+	tr := NewStatusTracker(NewClient("tok"))
+	ch, err := tr.Subscribe(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected an already-closed channel for no IDs")
+	}
+}
+
+func TestStatusTracker_WaitAll_ReachesFinalStatus(t *testing.T) {
+	server := statusServer(t, func(call int) []testStatusEntry {
+		status := StatusSending
+		if call >= 2 {
+			status = StatusDelivered
+		}
+		return []testStatusEntry{{"79001234567", status, 1}}
+	})
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	tr := NewStatusTracker(c, WithPollInterval(10*time.Millisecond), WithTrackerTimeout(time.Second))
+
+	result, err := tr.WaitAll(context.Background(), []int{1001})
+	if err != nil {
+		t.Fatalf("WaitAll failed: %v", err)
+	}
+	got, ok := result[1001]
+	if !ok {
+		t.Fatalf("expected a result for message 1001, got %+v", result)
+	}
+	if got.Status != StatusDelivered {
+		t.Errorf("Status = %q, want %q", got.Status, StatusDelivered)
+	}
+}
+
+func TestStatusTracker_DedupesRepeatedStatus(t *testing.T) {
+	server := statusServer(t, func(call int) []testStatusEntry {
+		status := StatusSending
+		if call >= 5 {
+			status = StatusDelivered
+		}
+		return []testStatusEntry{{"79001234567", status, 1}}
+	})
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	tr := NewStatusTracker(c, WithPollInterval(5*time.Millisecond), WithTrackerTimeout(time.Second))
+
+	ch, err := tr.Subscribe(context.Background(), []int{1001})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	var transitions []string
+	for status := range ch {
+		transitions = append(transitions, status.Status)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("expected exactly 2 transitions (Sending, Delivered), got %v", transitions)
+	}
+	if transitions[0] != StatusSending || transitions[1] != StatusDelivered {
+		t.Errorf("unexpected transitions: %v", transitions)
+	}
+}
+
+func TestStatusTracker_StopsWhenContextCancelled(t *testing.T) {
+	server := statusServer(t, func(call int) []testStatusEntry {
+		return []testStatusEntry{{"79001234567", StatusSending, 0}}
+	})
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	tr := NewStatusTracker(c, WithPollInterval(5*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := tr.Subscribe(ctx, []int{1001})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	<-ch // consume the first (and only) transition: Sending
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected no further statuses after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel did not close after context cancellation")
+	}
+}
+
+func TestStatusTracker_StopsAtTimeoutWithoutFinalStatus(t *testing.T) {
+	server := statusServer(t, func(call int) []testStatusEntry {
+		return []testStatusEntry{{"79001234567", StatusSending, 0}}
+	})
+	defer server.Close()
+
+	c := NewClient("tok", withStatusURLTempl(server.URL+"?messageIDs=%s"))
+	tr := NewStatusTracker(c,
+		WithPollInterval(5*time.Millisecond),
+		WithTrackerTimeout(30*time.Millisecond),
+	)
+
+	start := time.Now()
+	result, err := tr.WaitAll(context.Background(), []int{1001})
+	if err != nil {
+		t.Fatalf("WaitAll failed: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("WaitAll did not respect the tracker timeout")
+	}
+	if got := result[1001].Status; got != StatusSending {
+		t.Errorf("expected last-seen status %q, got %q", StatusSending, got)
+	}
+}
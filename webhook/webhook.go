@@ -0,0 +1,165 @@
+// Package webhook receives delivery-receipt push callbacks from the MTS
+// omni-adapter, as an alternative to polling smsmts.GetSMSStatuses.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dronm/smsmts"
+)
+
+// DefaultMaxSkew is the replay-protection window used when Handler.MaxSkew
+// is zero: a callback whose X-MTS-Timestamp is further than this from the
+// current time is rejected.
+const DefaultMaxSkew = 5 * time.Minute
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request, hex
+// encoded.
+const SignatureHeader = "X-MTS-Signature"
+
+// TimestampHeader carries the Unix timestamp (seconds) the callback was
+// signed at.
+const TimestampHeader = "X-MTS-Timestamp"
+
+// statusEvent is the payload shape of a single delivery-receipt callback.
+type statusEvent struct {
+	MessageID string  `json:"message_id"`
+	MsID      string  `json:"msid"`
+	Status    string  `json:"status"`
+	Cost      float64 `json:"cost"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Handler is an http.Handler that verifies and dispatches MTS
+// delivery-receipt callbacks.
+type Handler struct {
+	// Secret is the shared secret used to verify SignatureHeader.
+	Secret string
+	// MaxSkew bounds how old/future-dated a callback's TimestampHeader may
+	// be before it is rejected as a replay. Defaults to DefaultMaxSkew.
+	MaxSkew time.Duration
+	// OnStatus is called once per status event in a verified callback.
+	OnStatus func(smsmts.MessageStatus)
+}
+
+// Option configures a Handler constructed via NewHandler.
+type Option func(*Handler)
+
+// WithMaxSkew overrides the replay-protection window.
+func WithMaxSkew(d time.Duration) Option {
+	return func(h *Handler) { h.MaxSkew = d }
+}
+
+// NewHandler creates a Handler that verifies callbacks against secret and
+// forwards each status event to onStatus.
+func NewHandler(secret string, onStatus func(smsmts.MessageStatus), opts ...Option) *Handler {
+	h := &Handler{
+		Secret:   secret,
+		MaxSkew:  DefaultMaxSkew,
+		OnStatus: onStatus,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// RegisterRoutes registers h to handle POST requests at path on mux.
+func RegisterRoutes(mux *http.ServeMux, path string, h *Handler) {
+	mux.Handle(path, h)
+}
+
+// ServeHTTP verifies the request signature and timestamp, then dispatches
+// each status event in the body to h.OnStatus.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get(TimestampHeader)
+	if err := h.checkTimestamp(timestamp); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.verifySignature(r.Header.Get(SignatureHeader), timestamp, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var events []statusEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	for _, ev := range events {
+		if h.OnStatus != nil {
+			h.OnStatus(smsmts.MessageStatus{
+				MessageID: ev.MessageID,
+				MsID:      ev.MsID,
+				Status:    ev.Status,
+				Cost:      ev.Cost,
+				Error:     ev.Error,
+			})
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) checkTimestamp(timestamp string) error {
+	if timestamp == "" {
+		return fmt.Errorf("missing %s header", TimestampHeader)
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %v", TimestampHeader, err)
+	}
+
+	maxSkew := h.MaxSkew
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxSkew
+	}
+
+	skew := time.Since(time.Unix(sec, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return fmt.Errorf("timestamp outside allowed skew of %s", maxSkew)
+	}
+	return nil
+}
+
+func (h *Handler) verifySignature(signature, timestamp string, body []byte) error {
+	if signature == "" {
+		return fmt.Errorf("missing %s header", SignatureHeader)
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
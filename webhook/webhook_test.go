@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dronm/smsmts"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandler_ValidSignature(t *testing.T) {
+	var received []smsmts.MessageStatus
+	h := NewHandler("s3cr3t", func(s smsmts.MessageStatus) {
+		received = append(received, s)
+	})
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	body, _ := json.Marshal([]map[string]any{
+		{"message_id": "1001", "msid": "79001234567", "status": smsmts.StatusDelivered, "cost": 1.5},
+	})
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, sign("s3cr3t", timestamp, body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if len(received) != 1 || received[0].MessageID != "1001" || received[0].Status != smsmts.StatusDelivered {
+		t.Errorf("unexpected dispatched status: %+v", received)
+	}
+}
+
+func TestHandler_BadSignature(t *testing.T) {
+	h := NewHandler("s3cr3t", func(smsmts.MessageStatus) {
+		t.Error("OnStatus should not be called for a bad signature")
+	})
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	body := []byte(`[]`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "not-the-right-signature")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_StaleTimestamp(t *testing.T) {
+	h := NewHandler("s3cr3t", func(smsmts.MessageStatus) {
+		t.Error("OnStatus should not be called for a stale timestamp")
+	}, WithMaxSkew(time.Minute))
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	body := []byte(`[]`)
+	timestamp := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, sign("s3cr3t", timestamp, body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	h := NewHandler("s3cr3t", nil)
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	var received smsmts.MessageStatus
+	h := NewHandler("s3cr3t", func(s smsmts.MessageStatus) { received = s })
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, "/mts/delivery-receipts", h)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	body, _ := json.Marshal([]map[string]any{
+		{"message_id": "42", "msid": "79001234567", "status": smsmts.StatusSent},
+	})
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, _ := http.NewRequest("POST", server.URL+"/mts/delivery-receipts", bytes.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, sign("s3cr3t", timestamp, body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if received.MessageID != "42" {
+		t.Errorf("unexpected dispatched status: %+v", received)
+	}
+}